@@ -0,0 +1,219 @@
+// Package migrator provides an up/down migration runner for the oci8
+// dialect. gorm's AutoMigrate is additive-only: it can't drop a column or
+// rename anything safely, and Oracle's mostly-auto-committing DDL makes
+// ad-hoc ALTER statements risky to run by hand. Migrations registered here
+// run in ID order, each inside a savepoint. The runner does not rewrite a
+// migration's own statements: a migration routes its DDL through
+// DBMS_UTILITY.EXEC_DDL_STATEMENT by calling ExecDDL, and because Oracle DDL
+// auto-commits, a migration that fails part-way can still leave the DDL it
+// already ran applied.
+package migrator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Migration describes a single versioned schema change. ID should sort
+// chronologically, e.g. "20240115093000".
+type Migration struct {
+	ID          string
+	Description string
+	Migrate     func(*gorm.DB) error
+	Rollback    func(*gorm.DB) error
+}
+
+// MigrationStatus reports whether a registered migration has been applied.
+type MigrationStatus struct {
+	ID          string
+	Description string
+	Applied     bool
+}
+
+const schemaMigrationsTable = "schema_migrations"
+
+var (
+	mu         sync.Mutex
+	registered []Migration
+)
+
+// RegisterMigration adds m to the set of known migrations. Call it from an
+// init() next to where m.Migrate and m.Rollback are defined; migrations are
+// sorted by ID before running, since init() ordering across files isn't
+// guaranteed.
+func RegisterMigration(m Migration) {
+	mu.Lock()
+	defer mu.Unlock()
+	registered = append(registered, m)
+}
+
+func sortedMigrations() []Migration {
+	mu.Lock()
+	defer mu.Unlock()
+	out := make([]Migration, len(registered))
+	copy(out, registered)
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func ensureSchemaMigrationsTable(db *gorm.DB) error {
+	if db.Dialect().HasTable(schemaMigrationsTable) {
+		return nil
+	}
+	return db.Exec(fmt.Sprintf(
+		"CREATE TABLE %s (ID VARCHAR2(32) PRIMARY KEY, APPLIED_AT TIMESTAMP)",
+		db.Dialect().Quote(schemaMigrationsTable),
+	)).Error
+}
+
+func appliedIDs(db *gorm.DB) (map[string]bool, error) {
+	rows, err := db.Raw(fmt.Sprintf("SELECT ID FROM %s", db.Dialect().Quote(schemaMigrationsTable))).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[string]bool{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		applied[id] = true
+	}
+	return applied, rows.Err()
+}
+
+// savepointName derives an Oracle-legal savepoint identifier from a
+// migration ID, which in practice is a numeric timestamp and so isn't a
+// legal identifier on its own.
+func savepointName(id string) string {
+	return "SP_" + id
+}
+
+// runInSavepoint runs fn inside a named savepoint so a failing migration
+// rolls back cleanly without discarding work done earlier in the same
+// transaction. This only protects the bookkeeping insert/delete around each
+// migration: Oracle DDL is effectively auto-committing, so a failure mid-way
+// through a migration's own statements can still leave partial DDL applied.
+func runInSavepoint(db *gorm.DB, id string, fn func(*gorm.DB) error) error {
+	name := savepointName(id)
+	if err := db.Exec(fmt.Sprintf("SAVEPOINT %s", name)).Error; err != nil {
+		return err
+	}
+	if err := fn(db); err != nil {
+		db.Exec(fmt.Sprintf("ROLLBACK TO SAVEPOINT %s", name))
+		return err
+	}
+	return nil
+}
+
+// ExecDDL runs statement through DBMS_UTILITY.EXEC_DDL_STATEMENT. Migrations
+// with more than one DDL statement should issue them this way instead of a
+// plain db.Exec, so they're serialized the same way regardless of how the
+// connection pool schedules the session.
+func ExecDDL(db *gorm.DB, statement string) error {
+	return db.Exec("BEGIN DBMS_UTILITY.EXEC_DDL_STATEMENT(:1); END;", statement).Error
+}
+
+// MigrateUp applies every registered migration that hasn't been applied yet,
+// in ID order (including any with an ID older than the last applied one), up
+// to and including targetID. An empty targetID applies everything pending.
+func MigrateUp(db *gorm.DB, targetID string) error {
+	if err := ensureSchemaMigrationsTable(db); err != nil {
+		return err
+	}
+	applied, err := appliedIDs(db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range sortedMigrations() {
+		if applied[m.ID] {
+			// Still check whether targetID was this already-applied
+			// migration before moving on, or MigrateUp would run every
+			// later pending migration instead of stopping at targetID.
+			if targetID != "" && m.ID == targetID {
+				break
+			}
+			continue
+		}
+
+		err := runInSavepoint(db, m.ID, func(tx *gorm.DB) error {
+			if err := m.Migrate(tx); err != nil {
+				return err
+			}
+			return tx.Exec(
+				fmt.Sprintf("INSERT INTO %s (ID, APPLIED_AT) VALUES (:1, SYSTIMESTAMP)", db.Dialect().Quote(schemaMigrationsTable)),
+				m.ID,
+			).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migrate %s: %v", m.ID, err)
+		}
+
+		if targetID != "" && m.ID == targetID {
+			break
+		}
+	}
+	return nil
+}
+
+// MigrateDown rolls back applied migrations, highest ID first, down to but
+// not including targetID. An empty targetID rolls back every applied
+// migration.
+func MigrateDown(db *gorm.DB, targetID string) error {
+	if err := ensureSchemaMigrationsTable(db); err != nil {
+		return err
+	}
+	applied, err := appliedIDs(db)
+	if err != nil {
+		return err
+	}
+
+	migrations := sortedMigrations()
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.ID == targetID {
+			break
+		}
+		if !applied[m.ID] {
+			continue
+		}
+
+		err := runInSavepoint(db, m.ID, func(tx *gorm.DB) error {
+			if err := m.Rollback(tx); err != nil {
+				return err
+			}
+			return tx.Exec(
+				fmt.Sprintf("DELETE FROM %s WHERE ID = :1", db.Dialect().Quote(schemaMigrationsTable)),
+				m.ID,
+			).Error
+		})
+		if err != nil {
+			return fmt.Errorf("rollback %s: %v", m.ID, err)
+		}
+	}
+	return nil
+}
+
+// MigrateStatus reports the apply state of every registered migration,
+// sorted by ID.
+func MigrateStatus(db *gorm.DB) ([]MigrationStatus, error) {
+	if err := ensureSchemaMigrationsTable(db); err != nil {
+		return nil, err
+	}
+	applied, err := appliedIDs(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []MigrationStatus
+	for _, m := range sortedMigrations() {
+		statuses = append(statuses, MigrationStatus{ID: m.ID, Description: m.Description, Applied: applied[m.ID]})
+	}
+	return statuses, nil
+}
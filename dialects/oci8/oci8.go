@@ -2,8 +2,12 @@
 package oci8
 
 import (
+	"bytes"
 	"database/sql"
+	"errors"
 	"fmt"
+	"hash/crc32"
+	"io"
 	"reflect"
 	"strconv"
 	"strings"
@@ -20,12 +24,66 @@ var _ gorm.Dialect = (*oci8)(nil)
 type oci8 struct {
 	db gorm.SQLCommon
 	gorm.DefaultForeignKeyNamer
+
+	// preserveIdentifierCase, when true, leaves identifiers passed to QuoteTo
+	// (and the lookups that key off them) exactly as given instead of
+	// upper-casing them, which is Oracle's own default for unquoted
+	// identifiers.
+	preserveIdentifierCase bool
+
+	// serverMajorVersion is detected once in SetDB and cached so DataTypeOf
+	// can tell whether GENERATED ... AS IDENTITY is available. It's 0 when
+	// detection hasn't run or failed, which supportsIdentityColumns treats
+	// as "assume 12c or newer" to match this dialect's behavior before
+	// version detection existed.
+	serverMajorVersion int
+}
+
+// PreserveIdentifierCase controls whether QuoteTo (and the catalog lookups
+// built on top of it) upper-case identifiers before quoting them, which
+// matches how Oracle folds unquoted identifiers by default. Call it once
+// after registering the dialect if your schema objects were created with
+// mixed- or lower-case names.
+func (s *oci8) PreserveIdentifierCase(preserve bool) {
+	s.preserveIdentifierCase = preserve
+}
+
+// normalizeIdentifier applies the same case-folding QuoteTo uses, so code
+// that compares against Oracle's data dictionary (ALL_TAB_COLUMNS and
+// friends) stays consistent with how identifiers are quoted in generated
+// SQL.
+func (s oci8) normalizeIdentifier(identifier string) string {
+	if s.preserveIdentifierCase {
+		return identifier
+	}
+	return strings.ToUpper(identifier)
 }
 
 func init() {
 	gorm.RegisterDialect(dialectName, &oci8{})
 }
 
+// supportsIdentityColumns reports whether the connected server is new
+// enough (Oracle 12c+) to support GENERATED ... AS IDENTITY columns.
+func (s oci8) supportsIdentityColumns() bool {
+	return s.serverMajorVersion == 0 || s.serverMajorVersion >= 12
+}
+
+// detectServerVersion queries the connected instance's major version once so
+// DataTypeOf knows whether to fall back to a plain NUMBER column plus a
+// sequence and trigger on Oracle releases older than 12c. Detection errors
+// are ignored; supportsIdentityColumns then assumes 12c or newer, same as
+// this dialect's behavior before version detection existed.
+func (s *oci8) detectServerVersion() {
+	var version string
+	if err := s.db.QueryRow("SELECT VERSION FROM V$INSTANCE").Scan(&version); err != nil {
+		return
+	}
+	if major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0]); err == nil {
+		s.serverMajorVersion = major
+	}
+}
+
 func (s *oci8) fieldCanAutoIncrement(field *gorm.StructField) bool {
 	if value, ok := field.TagSettingsGet("AUTO_INCREMENT"); ok {
 		return strings.ToLower(value) != "false"
@@ -48,6 +106,31 @@ func (oci8) Quote(key string) string {
 	return key
 }
 
+// QuoteTo writes value to buf as a double-quoted Oracle identifier. Dotted
+// identifiers (e.g. "hr.employees") are split on "." and each segment is
+// quoted on its own, so schema-qualified names resolve correctly. Values
+// that are already quoted (starting with `"` or `` ` ``) are written
+// unchanged. Unless PreserveIdentifierCase has been set, segments are
+// upper-cased before quoting to match Oracle's own default resolution of
+// unquoted identifiers.
+func (s oci8) QuoteTo(buf *bytes.Buffer, value string) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "`") {
+		buf.WriteString(value)
+		return
+	}
+
+	segments := strings.Split(value, ".")
+	for i, segment := range segments {
+		if i > 0 {
+			buf.WriteByte('.')
+		}
+		buf.WriteByte('"')
+		buf.WriteString(s.normalizeIdentifier(segment))
+		buf.WriteByte('"')
+	}
+}
+
 func (s oci8) CurrentDatabase() string {
 	var name string
 	if err := s.db.QueryRow("SELECT ORA_DATABASE_NAME as \"Current Database\" FROM DUAL").Scan(&name); err != nil {
@@ -62,9 +145,15 @@ func (oci8) DefaultValueStr() string {
 
 func (s oci8) HasColumn(tableName string, columnName string) bool {
 	var count int
-	_, tableName = currentDatabaseAndTable(&s, tableName)
-	tableName = strings.ToUpper(tableName)
-	columnName = strings.ToUpper(columnName)
+	schemaName, tableName := currentDatabaseAndTable(&s, tableName)
+	tableName = s.normalizeIdentifier(tableName)
+	columnName = s.normalizeIdentifier(columnName)
+	if schemaName != "" {
+		if err := s.db.QueryRow("SELECT count(*) FROM ALL_TAB_COLUMNS WHERE OWNER = :1 AND TABLE_NAME = :2 AND COLUMN_NAME = :3", s.normalizeIdentifier(schemaName), tableName, columnName).Scan(&count); err == nil {
+			return count > 0
+		}
+		return false
+	}
 	if err := s.db.QueryRow("SELECT count(*) FROM ALL_TAB_COLUMNS WHERE TABLE_NAME = :1 AND COLUMN_NAME = :2", tableName, columnName).Scan(&count); err == nil {
 		return count > 0
 	}
@@ -73,8 +162,8 @@ func (s oci8) HasColumn(tableName string, columnName string) bool {
 
 func (s oci8) HasForeignKey(tableName string, foreignKeyName string) bool {
 	var count int
-	tableName = strings.ToUpper(tableName)
-	foreignKeyName = strings.ToUpper(foreignKeyName)
+	tableName = s.normalizeIdentifier(tableName)
+	foreignKeyName = s.normalizeIdentifier(foreignKeyName)
 
 	if err := s.db.QueryRow(`SELECT count(*) FROM USER_CONSTRAINTS WHERE CONSTRAINT_NAME = :1 AND constraint_type = 'R' AND table_name = :2`, foreignKeyName, tableName).Scan(&count); err == nil {
 		return count > 0
@@ -84,8 +173,8 @@ func (s oci8) HasForeignKey(tableName string, foreignKeyName string) bool {
 
 func (s oci8) HasIndex(tableName string, indexName string) bool {
 	var count int
-	tableName = strings.ToUpper(tableName)
-	indexName = strings.ToUpper(indexName)
+	tableName = s.normalizeIdentifier(tableName)
+	indexName = s.normalizeIdentifier(indexName)
 	if err := s.db.QueryRow("SELECT count(*) FROM ALL_INDEXES WHERE INDEX_NAME = :1 AND TABLE_NAME = :2", indexName, tableName).Scan(&count); err == nil {
 		return count > 0
 	}
@@ -94,8 +183,14 @@ func (s oci8) HasIndex(tableName string, indexName string) bool {
 
 func (s oci8) HasTable(tableName string) bool {
 	var count int
-	_, tableName = currentDatabaseAndTable(&s, tableName)
-	tableName = strings.ToUpper(tableName)
+	schemaName, tableName := currentDatabaseAndTable(&s, tableName)
+	tableName = s.normalizeIdentifier(tableName)
+	if schemaName != "" {
+		if err := s.db.QueryRow("select count(*) from all_tables where owner = :1 and table_name = :2", s.normalizeIdentifier(schemaName), tableName).Scan(&count); err == nil {
+			return count > 0
+		}
+		return false
+	}
 	if err := s.db.QueryRow("select count(*) from user_tables where table_name = :1", tableName).Scan(&count); err == nil {
 		return count > 0
 	}
@@ -111,12 +206,17 @@ func (oci8) LastInsertIDOutputInterstitial(tableName, columnName string, columns
 }
 
 func (s oci8) ModifyColumn(tableName string, columnName string, typ string) error {
-	_, err := s.db.Exec(fmt.Sprintf("ALTER TABLE %v MODIFY %v %v", tableName, columnName, typ))
+	var tableBuf, columnBuf bytes.Buffer
+	s.QuoteTo(&tableBuf, tableName)
+	s.QuoteTo(&columnBuf, columnName)
+	_, err := s.db.Exec(fmt.Sprintf("ALTER TABLE %v MODIFY %v %v", tableBuf.String(), columnBuf.String(), typ))
 	return err
 }
 
 func (s oci8) RemoveIndex(tableName string, indexName string) error {
-	_, err := s.db.Exec(fmt.Sprintf("DROP INDEX %v", indexName))
+	var buf bytes.Buffer
+	s.QuoteTo(&buf, indexName)
+	_, err := s.db.Exec(fmt.Sprintf("DROP INDEX %v", buf.String()))
 	return err
 }
 
@@ -126,14 +226,19 @@ func (oci8) SelectFromDummyTable() string {
 
 func (s *oci8) SetDB(db gorm.SQLCommon) {
 	s.db = db
+	s.detectServerVersion()
 }
 
+// currentDatabaseAndTable splits a possibly schema-qualified table name
+// (e.g. "hr.employees") into its schema and table parts. If tableName isn't
+// schema-qualified, the schema return value is "" and callers should fall
+// back to the connection's current schema.
 func currentDatabaseAndTable(dialect gorm.Dialect, tableName string) (string, string) {
 	if strings.Contains(tableName, ".") {
 		splitStrings := strings.SplitN(tableName, ".", 2)
 		return splitStrings[0], splitStrings[1]
 	}
-	return dialect.CurrentDatabase(), tableName
+	return "", tableName
 }
 
 func (s *oci8) DataTypeOf(field *gorm.StructField) string {
@@ -142,13 +247,32 @@ func (s *oci8) DataTypeOf(field *gorm.StructField) string {
 	}
 	var dataValue, sqlType, size, additionalType = gorm.ParseFieldStructForDialect(field, s)
 
+	// A `gorm:"lob:stream"` field is streamed through LobReader/LobWriter
+	// instead of being scanned or inserted like a normal column (see
+	// RegisterLobCallbacks), so its column type is decided here from the Go
+	// type alone, ignoring SIZE.
+	if lobMode, ok := field.TagSettingsGet("LOB"); ok && strings.EqualFold(lobMode, "stream") {
+		if gorm.IsByteArrayOrSlice(dataValue) {
+			sqlType = "BLOB"
+		} else {
+			sqlType = "CLOB"
+		}
+	}
+
 	if sqlType == "" {
 		switch dataValue.Kind() {
 		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8,
 			reflect.Uint16, reflect.Uintptr, reflect.Int64, reflect.Uint32, reflect.Uint64,
 			reflect.Float32, reflect.Float64:
 			if s.fieldCanAutoIncrement(field) {
-				sqlType = "NUMBER GENERATED BY DEFAULT AS IDENTITY"
+				if s.supportsIdentityColumns() {
+					sqlType = "NUMBER GENERATED BY DEFAULT AS IDENTITY"
+				} else {
+					// Pre-12c has no identity columns; EnsureAutoIncrement
+					// backs this column with a sequence and BEFORE INSERT
+					// trigger instead.
+					sqlType = "NUMBER"
+				}
 			} else {
 				switch dataValue.Kind() {
 				case reflect.Int8,
@@ -241,6 +365,98 @@ func (oci8) NormalizeIndexAndColumn(indexName, columnName string) (string, strin
 	return indexName, columnName
 }
 
+// maxOracleIdentifierLength is the identifier length limit on the pre-12c
+// (strictly, pre-12.2) servers EnsureAutoIncrement targets; 12.2 raised it to
+// 128 bytes, but these sequences/triggers only exist to backfill identity
+// support on servers that don't have it.
+const maxOracleIdentifierLength = 30
+
+// identityObjectName derives a sequence/trigger name from prefix, tableName
+// and columnName. The straightforward "PREFIX_TABLE_COLUMN" form is used
+// when it fits; otherwise it's truncated and given a short checksum suffix
+// so it still fits within maxOracleIdentifierLength without colliding with
+// the name for a different table/column that truncates the same way.
+func identityObjectName(prefix, tableName, columnName string) string {
+	name := fmt.Sprintf("%s_%s_%s", prefix, tableName, columnName)
+	if len(name) <= maxOracleIdentifierLength {
+		return name
+	}
+
+	suffix := fmt.Sprintf("_%08X", crc32.ChecksumIEEE([]byte(tableName+"."+columnName)))
+	keep := maxOracleIdentifierLength - len(suffix)
+	if keep < 0 {
+		keep = 0
+	}
+	return name[:keep] + suffix
+}
+
+func (s oci8) hasSequence(sequenceName string) bool {
+	var count int
+	if err := s.db.QueryRow("SELECT count(*) FROM USER_SEQUENCES WHERE SEQUENCE_NAME = :1", strings.ToUpper(sequenceName)).Scan(&count); err == nil {
+		return count > 0
+	}
+	return false
+}
+
+func (s oci8) hasTrigger(triggerName string) bool {
+	var count int
+	if err := s.db.QueryRow("SELECT count(*) FROM USER_TRIGGERS WHERE TRIGGER_NAME = :1", strings.ToUpper(triggerName)).Scan(&count); err == nil {
+		return count > 0
+	}
+	return false
+}
+
+// EnsureAutoIncrement creates the sequence and BEFORE INSERT trigger backing
+// an auto-increment primary key on servers where DataTypeOf fell back to a
+// plain NUMBER column because GENERATED ... AS IDENTITY isn't available
+// (Oracle releases older than 12c). It's idempotent: existing sequence or
+// trigger objects are left alone. Call it once per model after AutoMigrate
+// when targeting such a server:
+//
+//	db.AutoMigrate(&User{})
+//	dialect.EnsureAutoIncrement(db, &User{})
+//
+// Columns tagged `gorm:"NOTRIGGER"` are skipped, for callers who assign
+// sequence values themselves. CreateWithReturningInto needs no changes to
+// pick up trigger-populated values: it already reads the primary key back
+// with RETURNING ... INTO regardless of what populated it.
+func (s oci8) EnsureAutoIncrement(db *gorm.DB, value interface{}) error {
+	scope := db.NewScope(value)
+	tableName := scope.TableName()
+
+	for _, field := range scope.Fields() {
+		if !field.IsPrimaryKey || !s.fieldCanAutoIncrement(field.StructField) {
+			continue
+		}
+		if _, noTrigger := field.TagSettingsGet("NOTRIGGER"); noTrigger {
+			continue
+		}
+
+		column := s.Quote(field.DBName)
+		sequenceName := identityObjectName("SEQ", tableName, field.DBName)
+		if !s.hasSequence(sequenceName) {
+			if _, err := s.db.Exec(fmt.Sprintf("CREATE SEQUENCE %s", s.Quote(sequenceName))); err != nil {
+				return err
+			}
+		}
+
+		triggerName := identityObjectName("TRG", tableName, field.DBName)
+		if !s.hasTrigger(triggerName) {
+			trigger := fmt.Sprintf(`CREATE OR REPLACE TRIGGER %s
+BEFORE INSERT ON %s
+FOR EACH ROW
+WHEN (NEW.%s IS NULL)
+BEGIN
+  SELECT %s.NEXTVAL INTO :NEW.%s FROM DUAL;
+END;`, s.Quote(triggerName), s.Quote(tableName), column, s.Quote(sequenceName), column)
+			if _, err := s.db.Exec(trigger); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (oci8) CreateWithReturningInto(scope *gorm.Scope) {
 	var stringId string
 	var intId uint32
@@ -269,14 +485,562 @@ func (oci8) CreateWithReturningInto(scope *gorm.Scope) {
 	// this should raise an error, but the gorm.createCallback() which calls it simply doesn't support returning an error
 }
 
-// SearchBlob returns a where clause substring for searching fieldName and will require you to pass a parameter for the search value
-func SearchBlob(fieldName string) string {
+// upsertOptionKey is the gorm.Scope setting Upsert looks for, set via
+// WithUpsert. Oracle's MERGE can't be expressed as a suffix on INSERT the
+// way "gorm:insert_option" works for other dialects, so oci8 keys its own
+// option and RegisterUpsertCallback wires it into the create chain.
+const upsertOptionKey = "oci8:upsert_option"
+
+// UpsertOption carries the columns used to match an existing row (typically
+// the primary or a unique key) and the columns to refresh when a match is
+// found.
+type UpsertOption struct {
+	ConflictColumns []string
+	UpdateColumns   []string
+}
+
+// WithUpsert marks db so the next Create performs an Oracle MERGE instead of
+// a plain INSERT, matching rows on conflictColumns and refreshing
+// updateColumns when one already exists. RegisterUpsertCallback must have
+// been called on db once beforehand.
+func WithUpsert(db *gorm.DB, conflictColumns []string, updateColumns []string) *gorm.DB {
+	return db.Set(upsertOptionKey, UpsertOption{ConflictColumns: conflictColumns, UpdateColumns: updateColumns})
+}
+
+// RegisterUpsertCallback installs the hook that lets WithUpsert take effect.
+// Call it once after opening db:
+//
+//	oci8.RegisterUpsertCallback(db)
+//	oci8.WithUpsert(db, []string{"id"}, []string{"name"}).Create(&record)
+func RegisterUpsertCallback(db *gorm.DB) {
+	db.Callback().Create().Before("gorm:create").Register("oci8:upsert", func(scope *gorm.Scope) {
+		option, ok := scope.Get(upsertOptionKey)
+		if !ok {
+			return
+		}
+		upsertOption, ok := option.(UpsertOption)
+		if !ok {
+			return
+		}
+		dialect, ok := scope.Dialect().(*oci8)
+		if !ok {
+			return
+		}
+		dialect.Upsert(scope, upsertOption.ConflictColumns, upsertOption.UpdateColumns)
+		scope.SkipLeft()
+	})
+}
+
+// Upsert emits an Oracle MERGE INTO ... USING (SELECT ... FROM DUAL) src ...
+// WHEN NOT MATCHED THEN INSERT ... statement in place of scope's plain
+// INSERT, matching existing rows on conflictColumns and refreshing
+// updateColumns when one is found (the WHEN MATCHED clause is left out
+// entirely when updateColumns is empty, since "UPDATE SET" needs at least
+// one assignment). Primary key columns that can auto-increment (see
+// fieldCanAutoIncrement) are left out of the statement entirely unless
+// they're also a conflict column; other columns tagged AUTO_INCREMENT are
+// still inserted.
+//
+// Oracle doesn't support RETURNING on MERGE, so unlike
+// CreateWithReturningInto this can't read back a generated primary key from
+// the statement itself; callers that need the key back should read it with
+// a follow-up SELECT keyed on conflictColumns.
+func (s oci8) Upsert(scope *gorm.Scope, conflictColumns []string, updateColumns []string) {
+	isConflictColumn := make(map[string]bool, len(conflictColumns))
+	for _, column := range conflictColumns {
+		isConflictColumn[strings.ToLower(column)] = true
+	}
+	isUpdateColumn := make(map[string]bool, len(updateColumns))
+	for _, column := range updateColumns {
+		isUpdateColumn[strings.ToLower(column)] = true
+	}
+
+	var vars []interface{}
+	nextBindVar := func(value interface{}) string {
+		vars = append(vars, value)
+		return fmt.Sprintf(":%d", len(vars))
+	}
+
+	var srcSelect, matchOn, updates, insertColumns, insertValues []string
+	for _, field := range scope.Fields() {
+		if field.IsIgnored || !field.IsNormal {
+			continue
+		}
+		quotedColumn := scope.Quote(field.DBName)
+		if field.IsPrimaryKey && s.fieldCanAutoIncrement(field.StructField) && !isConflictColumn[strings.ToLower(field.DBName)] {
+			continue
+		}
+
+		srcSelect = append(srcSelect, fmt.Sprintf("%s %s", nextBindVar(field.Field.Interface()), quotedColumn))
+
+		if isConflictColumn[strings.ToLower(field.DBName)] {
+			matchOn = append(matchOn, fmt.Sprintf("target.%s = src.%s", quotedColumn, quotedColumn))
+		} else if isUpdateColumn[strings.ToLower(field.DBName)] {
+			updates = append(updates, fmt.Sprintf("%s = src.%s", quotedColumn, quotedColumn))
+		}
+
+		// Every column not skipped above - including conflict
+		// columns - needs to be in the INSERT list, or a brand-new row would
+		// be inserted with the key left NULL.
+		insertColumns = append(insertColumns, quotedColumn)
+		insertValues = append(insertValues, fmt.Sprintf("src.%s", quotedColumn))
+	}
+
+	var whenMatched string
+	if len(updates) > 0 {
+		whenMatched = fmt.Sprintf("WHEN MATCHED THEN UPDATE SET %s ", strings.Join(updates, ", "))
+	}
+
+	query := fmt.Sprintf(
+		"MERGE INTO %s target USING (SELECT %s FROM DUAL) src ON (%s) %sWHEN NOT MATCHED THEN INSERT (%s) VALUES (%s)",
+		scope.QuotedTableName(),
+		strings.Join(srcSelect, ", "),
+		strings.Join(matchOn, " AND "),
+		whenMatched,
+		strings.Join(insertColumns, ", "),
+		strings.Join(insertValues, ", "),
+	)
+
+	if result, err := scope.SQLDB().Exec(query, vars...); scope.Err(err) == nil {
+		scope.DB().RowsAffected, _ = result.RowsAffected()
+	}
+	// this should raise an error, but the gorm.createCallback() which calls it simply doesn't support returning an error
+}
+
+// maxInsertAllBranches is Oracle's limit on the number of expressions a
+// single INSERT ALL statement may contain; BulkInsert chunks batches to stay
+// under it.
+const maxInsertAllBranches = 1000
+
+// BulkInsertOptions configures BulkInsert's batching behaviour.
+type BulkInsertOptions struct {
+	// BatchSize is the number of rows sent per round trip. Zero uses the
+	// default of 500. Batches are further split to respect Oracle's
+	// maxInsertAllBranches limit on the INSERT ALL fallback path.
+	BatchSize int
+}
+
+// bindArraySupporter is implemented by go-oci8 connections that can bind Go
+// slices as PL/SQL associative arrays. gorm.SQLCommon hides the concrete
+// driver connection, so BulkInsert detects the capability through this
+// narrow interface instead of importing go-oci8 internals.
+type bindArraySupporter interface {
+	SupportsBindArrays() bool
+}
+
+// BulkInsert writes rows (each a pointer to a struct of scope's model type)
+// in batches instead of one round trip per row. When the connection
+// implements bindArraySupporter, each batch runs as a single anonymous
+// PL/SQL block with a FORALL INSERT, which is dramatically faster than
+// INSERT ALL for wide tables; generated primary keys are read back with
+// RETURNING ... BULK COLLECT INTO and scattered onto rows by index.
+// Otherwise each batch falls back to INSERT ALL INTO t (...) VALUES (...)
+// ... SELECT 1 FROM DUAL, chunked to stay under Oracle's 1000-expression
+// limit; Oracle doesn't allow RETURNING on INSERT ALL, so that path leaves
+// primary keys untouched.
+func (s oci8) BulkInsert(scope *gorm.Scope, rows []interface{}, opts BulkInsertOptions) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	rowScopes := make([]*gorm.Scope, len(rows))
+	for i, row := range rows {
+		rowScopes[i] = scope.NewDB().NewScope(row)
+	}
+
+	var columns []string
+	for _, field := range rowScopes[0].Fields() {
+		if field.IsIgnored || !field.IsNormal || (field.IsPrimaryKey && s.fieldCanAutoIncrement(field.StructField)) {
+			continue
+		}
+		columns = append(columns, field.DBName)
+	}
+
+	supportsBindArrays := false
+	if supporter, ok := s.db.(bindArraySupporter); ok {
+		supportsBindArrays = supporter.SupportsBindArrays()
+	}
+
+	tableName := scope.QuotedTableName()
+	primaryField := rowScopes[0].PrimaryField()
+
+	for start := 0; start < len(rowScopes); start += batchSize {
+		end := start + batchSize
+		if end > len(rowScopes) {
+			end = len(rowScopes)
+		}
+		batch := rowScopes[start:end]
+
+		if supportsBindArrays {
+			if err := s.forallInsert(batch, tableName, columns, primaryField); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, chunk := range chunkRowScopes(batch, len(columns), maxInsertAllBranches) {
+			if err := s.insertAllChunk(chunk, tableName, columns); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// chunkRowScopes splits rowScopes so that columnCount * len(chunk) stays at
+// or under maxExpressions.
+func chunkRowScopes(rowScopes []*gorm.Scope, columnCount, maxExpressions int) [][]*gorm.Scope {
+	if columnCount == 0 {
+		columnCount = 1
+	}
+	perChunk := maxExpressions / columnCount
+	if perChunk == 0 {
+		perChunk = 1
+	}
+
+	var chunks [][]*gorm.Scope
+	for start := 0; start < len(rowScopes); start += perChunk {
+		end := start + perChunk
+		if end > len(rowScopes) {
+			end = len(rowScopes)
+		}
+		chunks = append(chunks, rowScopes[start:end])
+	}
+	return chunks
+}
+
+// insertAllChunk inserts rowScopes with a single INSERT ALL ... SELECT 1
+// FROM DUAL statement.
+func (s oci8) insertAllChunk(rowScopes []*gorm.Scope, tableName string, columns []string) error {
+	quotedColumns := make([]string, len(columns))
+	for i, column := range columns {
+		quotedColumns[i] = s.Quote(column)
+	}
+
+	var branches []string
+	var vars []interface{}
+	for _, rs := range rowScopes {
+		placeholders := make([]string, len(columns))
+		for i, column := range columns {
+			field, _ := rs.FieldByName(column)
+			vars = append(vars, field.Field.Interface())
+			placeholders[i] = fmt.Sprintf(":%d", len(vars))
+		}
+		branches = append(branches, fmt.Sprintf("INTO %s (%s) VALUES (%s)", tableName, strings.Join(quotedColumns, ", "), strings.Join(placeholders, ", ")))
+	}
+
+	query := fmt.Sprintf("INSERT ALL %s SELECT 1 FROM DUAL", strings.Join(branches, " "))
+	_, err := s.db.Exec(query, vars...)
+	return err
+}
+
+// forallInsert binds each column as a Go slice and runs a single anonymous
+// PL/SQL block with a FORALL INSERT so the whole batch round-trips once,
+// then scatters generated primary keys back onto rowScopes by index.
+func (s oci8) forallInsert(rowScopes []*gorm.Scope, tableName string, columns []string, primaryField *gorm.Field) error {
+	quotedColumns := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	vars := make([]interface{}, len(columns))
+	for i, column := range columns {
+		quotedColumns[i] = s.Quote(column)
+		placeholders[i] = fmt.Sprintf(":%d(i)", i+1)
+
+		values := make([]interface{}, len(rowScopes))
+		for j, rs := range rowScopes {
+			field, _ := rs.FieldByName(column)
+			values[j] = field.Field.Interface()
+		}
+		vars[i] = values
+	}
+
+	primaryIsString := primaryField.Field.Kind() == reflect.String
+	var ids sql.Out
+	if primaryIsString {
+		ids = sql.Out{Dest: new([]string)}
+	} else {
+		ids = sql.Out{Dest: new([]uint32)}
+	}
+	idsBindVar := len(vars) + 1
+	vars = append(vars, ids)
+
+	block := fmt.Sprintf(`DECLARE
+  TYPE t_ids IS TABLE OF %[1]s.%[4]s%%TYPE;
+  v_ids t_ids;
+BEGIN
+  FORALL i IN 1 .. :1.COUNT
+    INSERT INTO %[1]s (%[2]s) VALUES (%[3]s)
+    RETURNING %[4]s BULK COLLECT INTO v_ids;
+  :%[5]d := v_ids;
+END;`,
+		tableName, strings.Join(quotedColumns, ", "), strings.Join(placeholders, ", "),
+		s.Quote(primaryField.DBName), idsBindVar)
+
+	if _, err := s.db.Exec(block, vars...); err != nil {
+		return err
+	}
+
+	if primaryIsString {
+		values := *ids.Dest.(*[]string)
+		for i, rs := range rowScopes {
+			if i < len(values) {
+				rs.PrimaryField().Set(values[i])
+			}
+		}
+	} else {
+		values := *ids.Dest.(*[]uint32)
+		for i, rs := range rowScopes {
+			if i < len(values) {
+				rs.PrimaryField().Set(values[i])
+			}
+		}
+	}
+	return nil
+}
+
+// SearchBlob returns a where clause substring for searching fieldName,
+// starting at offset, and will require you to pass a parameter for the
+// search value.
+func SearchBlob(fieldName string, offset int) string {
 	// oracle requires some hoop jumping to search []byte stored as BLOB
 
 	const lobSearch = ` dbms_lob.instr (%s, -- the blob
 		utl_raw.cast_to_raw (?), -- the search string cast to raw
+		%d, -- where to start. i.e. offset
+		1 -- Which occurrance i.e. 1=first
+		 ) > 0 `
+	return fmt.Sprintf(lobSearch, fieldName, offset)
+}
+
+// SearchClob returns a where clause substring for searching fieldName, a
+// CLOB column, and will require you to pass a parameter for the search
+// value. Unlike SearchBlob, no utl_raw.cast_to_raw is involved: a CLOB has
+// to be searched with a VARCHAR2 pattern directly.
+func SearchClob(fieldName string) string {
+	const lobSearch = ` dbms_lob.instr (%s, -- the clob
+		?, -- the search string
 		1, -- where to start. i.e. offset
 		1 -- Which occurrance i.e. 1=first
 		 ) > 0 `
 	return fmt.Sprintf(lobSearch, fieldName)
 }
+
+// RegisterLobCallbacks installs the callback that keeps columns tagged
+// `gorm:"lob:stream"` out of ordinary Query and Create statements, so large
+// BLOB/CLOB payloads only move through LobReader/LobWriter. Call it once
+// after opening db:
+//
+//	oci8.RegisterLobCallbacks(db)
+//
+// This omits the LOB columns per-operation via scope.Search.Omit rather than
+// flipping field.IsNormal on the *gorm.StructField returned by
+// scope.Fields(): that struct is shared process-wide (gorm caches one per
+// model in GetModelStruct()), so mutating it would race with every other
+// goroutine using the same model and would also make AutoMigrate think the
+// column doesn't exist, since table creation gates on IsNormal too.
+func RegisterLobCallbacks(db *gorm.DB) {
+	excludeLobColumns := func(scope *gorm.Scope) {
+		var lobColumns []string
+		for _, field := range scope.Fields() {
+			if _, ok := field.TagSettingsGet("LOB"); ok {
+				lobColumns = append(lobColumns, field.DBName)
+			}
+		}
+		if len(lobColumns) > 0 {
+			scope.Search = scope.Search.Omit(lobColumns...)
+		}
+	}
+	db.Callback().Query().Before("gorm:query").Register("oci8:lob_query", excludeLobColumns)
+	db.Callback().Create().Before("gorm:create").Register("oci8:lob_create", excludeLobColumns)
+}
+
+// lobPrimaryKeyColumn is the column LobReader and LobWriter assume holds the
+// row's primary key. Both only take a bare table/column pair, so they can't
+// discover a model's actual primary key column the way the rest of this
+// dialect does through *gorm.Scope.
+const lobPrimaryKeyColumn = "ID"
+
+// lobChunkSize is the default chunk size LobReader and LobWriter move per
+// round trip. A plain SQL expression like DBMS_LOB.SUBSTR is bound by
+// VARCHAR2/RAW's SQL-context limit (4000/2000 bytes under the default
+// MAX_STRING_SIZE=STANDARD), but a PL/SQL-local RAW/VARCHAR2 variable - which
+// is what DBMS_LOB.READ reads into below - can hold up to 32767 bytes
+// regardless of MAX_STRING_SIZE, so this stays just under that instead.
+const lobChunkSize = 32000
+
+// lobColumnDataType looks up a column's DATA_TYPE (BLOB or CLOB), needed to
+// pick the right PL/SQL locator and buffer types for DBMS_LOB.READ and
+// DBMS_LOB.WRITEAPPEND.
+func lobColumnDataType(db *gorm.DB, table, column string) (string, error) {
+	var dataType string
+	err := db.Raw("SELECT DATA_TYPE FROM USER_TAB_COLUMNS WHERE TABLE_NAME = :1 AND COLUMN_NAME = :2",
+		strings.ToUpper(table), strings.ToUpper(column)).Row().Scan(&dataType)
+	return dataType, err
+}
+
+// LobReader returns a ReadCloser streaming the BLOB or CLOB in table's
+// column for the row whose primary key is pk, reading it in lobChunkSize
+// pieces via an anonymous PL/SQL block around DBMS_LOB.READ rather than
+// pulling the whole value into memory. Reading doesn't need the row locked
+// across calls the way writing does, so unlike LobWriter this doesn't open a
+// transaction or take a FOR UPDATE lock.
+func LobReader(db *gorm.DB, table, column string, pk interface{}) (io.ReadCloser, error) {
+	dataType, err := lobColumnDataType(db, table, column)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lobReader{db: db, dialect: db.Dialect(), table: table, column: column, pk: pk, dataType: dataType}, nil
+}
+
+type lobReader struct {
+	db       *gorm.DB
+	dialect  gorm.Dialect
+	table    string
+	column   string
+	pk       interface{}
+	dataType string
+	offset   int64
+	done     bool
+}
+
+func (r *lobReader) readBlock(want int64) string {
+	bufferType := "RAW(32000)"
+	if strings.EqualFold(r.dataType, "CLOB") {
+		bufferType = "VARCHAR2(32000)"
+	}
+
+	return fmt.Sprintf(`DECLARE
+  v_lob %s;
+  v_buf %s;
+  v_amt PLS_INTEGER := :1;
+BEGIN
+  SELECT %s INTO v_lob FROM %s WHERE %s = :2;
+  BEGIN
+    DBMS_LOB.READ(v_lob, v_amt, :3, v_buf);
+  EXCEPTION
+    WHEN NO_DATA_FOUND THEN
+      v_buf := NULL;
+  END;
+  :4 := v_buf;
+END;`, r.dataType, bufferType, r.dialect.Quote(r.column), r.dialect.Quote(r.table), r.dialect.Quote(lobPrimaryKeyColumn))
+}
+
+func (r *lobReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	want := int64(len(p))
+	if want > lobChunkSize {
+		want = lobChunkSize
+	}
+	block := r.readBlock(want)
+
+	var chunk []byte
+	if strings.EqualFold(r.dataType, "CLOB") {
+		var text string
+		if err := r.db.Exec(block, want, r.pk, r.offset+1, sql.Out{Dest: &text}).Error; err != nil {
+			return 0, err
+		}
+		chunk = []byte(text)
+	} else if err := r.db.Exec(block, want, r.pk, r.offset+1, sql.Out{Dest: &chunk}).Error; err != nil {
+		return 0, err
+	}
+
+	n := copy(p, chunk)
+	r.offset += int64(n)
+	if int64(n) < want {
+		r.done = true
+		if n == 0 {
+			return 0, io.EOF
+		}
+	}
+	return n, nil
+}
+
+func (r *lobReader) Close() error {
+	return nil
+}
+
+// LobWriter returns a WriteCloser streaming data into the BLOB or CLOB in
+// table's column for the row whose primary key is pk, writing it in
+// lobChunkSize pieces via DBMS_LOB.WRITEAPPEND. The existing value is
+// truncated to empty as soon as the writer is opened. The row is locked FOR
+// UPDATE for the writer's lifetime; Close commits and releases it.
+func LobWriter(db *gorm.DB, table, column string, pk interface{}) (io.WriteCloser, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	dialect := tx.Dialect()
+	dataType, err := lobColumnDataType(tx, table, column)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	truncate := fmt.Sprintf(`DECLARE
+  v_lob %s;
+BEGIN
+  SELECT %s INTO v_lob FROM %s WHERE %s = :1 FOR UPDATE;
+  DBMS_LOB.TRIM(v_lob, 0);
+END;`, dataType, dialect.Quote(column), dialect.Quote(table), dialect.Quote(lobPrimaryKeyColumn))
+	if err := tx.Exec(truncate, pk).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return &lobWriter{tx: tx, dialect: dialect, table: table, column: column, pk: pk, dataType: dataType}, nil
+}
+
+type lobWriter struct {
+	tx       *gorm.DB
+	dialect  gorm.Dialect
+	table    string
+	column   string
+	pk       interface{}
+	dataType string
+	closed   bool
+}
+
+func (w *lobWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("oci8: write to closed LobWriter")
+	}
+
+	written := 0
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > lobChunkSize {
+			chunk = chunk[:lobChunkSize]
+		}
+
+		block := fmt.Sprintf(`DECLARE
+  v_lob %s;
+BEGIN
+  SELECT %s INTO v_lob FROM %s WHERE %s = :1 FOR UPDATE;
+  DBMS_LOB.WRITEAPPEND(v_lob, :2, :3);
+END;`, w.dataType, w.dialect.Quote(w.column), w.dialect.Quote(w.table), w.dialect.Quote(lobPrimaryKeyColumn))
+
+		if err := w.tx.Exec(block, w.pk, len(chunk), chunk).Error; err != nil {
+			return written, err
+		}
+
+		written += len(chunk)
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
+
+func (w *lobWriter) Close() error {
+	w.closed = true
+	return w.tx.Commit().Error
+}